@@ -0,0 +1,654 @@
+// Package installerstate analyzes installer pods and sets degraded conditions suggesting different
+// root causes. It started as a vendored copy of library-go's InstallerStateController; the disruption
+// reason conditions, WithInstallPrecondition, the configurable tolerations/backoff, and the image-pull
+// classification below were developed here, as a repo-owned package, rather than as a vendor patch on
+// top of openshift/library-go, since they aren't upstream yet.
+package installerstate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/klog/v2"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	applyoperatorv1 "github.com/openshift/client-go/operator/applyconfigurations/operator/v1"
+	"github.com/openshift/library-go/pkg/controller/factory"
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
+)
+
+const installerStateControllerWorkQueueKey = "key"
+
+// InstallerStateControllerOptions configures the per-controller tolerances and
+// event backoff used by an InstallerStateController. Use
+// NewConfigurableInstallerStateController to supply a non-default set.
+type InstallerStateControllerOptions struct {
+	// PendingTolerationDuration is the maximum time we tolerate an installer pod in the Pending phase
+	// before reporting it (or a precondition failure) as degraded.
+	PendingTolerationDuration time.Duration
+	// ContainerWaitingTolerationDuration is the maximum time we tolerate an installer pod's container
+	// in the Waiting state before reporting it as degraded.
+	ContainerWaitingTolerationDuration time.Duration
+	// NetworkEventLookbackWindow bounds how far back we look when matching namespace events to a pending pod's
+	// networking condition. Events older than this are ignored.
+	NetworkEventLookbackWindow time.Duration
+	// MinEventInterval is the minimum time between repeated Warning events for the same node carrying an
+	// unchanged reason and message. Defaults to 10 minutes.
+	MinEventInterval time.Duration
+}
+
+// defaultInstallerStateControllerOptions are the options used by NewInstallerStateController.
+var defaultInstallerStateControllerOptions = InstallerStateControllerOptions{
+	PendingTolerationDuration:          5 * time.Minute,
+	ContainerWaitingTolerationDuration: 5 * time.Minute,
+	NetworkEventLookbackWindow:         1 * time.Hour,
+	MinEventInterval:                   10 * time.Minute,
+}
+
+// emittedEvent records the last Warning event emitted for a node, so repeat syncs of the same
+// stuck pod don't re-fire it until the reason/message changes or MinEventInterval elapses.
+type emittedEvent struct {
+	reason    string
+	message   string
+	timestamp time.Time
+}
+
+// InstallPreconditionFunc is consulted for installer pods that have been
+// pending for longer than options.PendingTolerationDuration, before they are
+// reported as InstallerPodPendingDegraded. It lets a caller tell the
+// controller "this pod is stuck because we're intentionally holding it"
+// (ok=false, with a reason/message to surface) apart from an unexplained
+// failure.
+type InstallPreconditionFunc func(ctx context.Context, nodeName string) (ok bool, reason, message string, err error)
+
+// InstallerStateController analyzes installer pods and sets degraded conditions suggesting different root causes.
+type InstallerStateController struct {
+	controllerInstanceName          string
+	kubeInformersForTargetNamespace informers.SharedInformerFactory
+	podsGetter                      corev1client.PodsGetter
+	eventsGetter                    corev1client.EventsGetter
+	targetNamespace                 string
+	operatorClient                  v1helpers.StaticPodOperatorClient
+
+	timeNowFn func() time.Time
+
+	options InstallerStateControllerOptions
+
+	// lastSeenPodToNode records, from the previous sync, which node each
+	// current-revision installer pod was running on. It lets us notice pods that vanished
+	// between syncs (e.g. deleted by PodGC before we observed their
+	// DisruptionTarget condition) and attribute their disruption from events.
+	lastSeenPodToNode map[string]string
+
+	// lastEventEmitted tracks, per node, the last Warning event this controller emitted so that
+	// unchanged warnings aren't re-fired on every resync. See recordWarningf.
+	lastEventEmitted map[string]emittedEvent
+
+	// installPrecondition, when set, is consulted for pending installer pods
+	// before they are reported degraded. See WithInstallPrecondition.
+	installPrecondition InstallPreconditionFunc
+}
+
+// NewInstallerStateController keeps its original signature and immediately builds the
+// factory.Controller, for callers that don't need WithInstallPrecondition or non-default options. It is
+// a thin wrapper around NewConfigurableInstallerStateController.ToController.
+func NewInstallerStateController(instanceName string,
+	kubeInformersForTargetNamespace informers.SharedInformerFactory,
+	podsGetter corev1client.PodsGetter,
+	eventsGetter corev1client.EventsGetter,
+	operatorClient v1helpers.StaticPodOperatorClient,
+	targetNamespace string,
+	recorder events.Recorder,
+) factory.Controller {
+	return NewConfigurableInstallerStateController(
+		instanceName,
+		kubeInformersForTargetNamespace,
+		podsGetter,
+		eventsGetter,
+		operatorClient,
+		targetNamespace,
+		defaultInstallerStateControllerOptions,
+	).ToController(recorder)
+}
+
+// NewConfigurableInstallerStateController is like NewInstallerStateController but returns the
+// *InstallerStateController itself so the caller can chain WithInstallPrecondition and override the
+// pending/waiting tolerations and event backoff before building the factory.Controller with
+// ToController(recorder).
+func NewConfigurableInstallerStateController(instanceName string,
+	kubeInformersForTargetNamespace informers.SharedInformerFactory,
+	podsGetter corev1client.PodsGetter,
+	eventsGetter corev1client.EventsGetter,
+	operatorClient v1helpers.StaticPodOperatorClient,
+	targetNamespace string,
+	options InstallerStateControllerOptions,
+) *InstallerStateController {
+	if options.MinEventInterval == 0 {
+		options.MinEventInterval = defaultInstallerStateControllerOptions.MinEventInterval
+	}
+	return &InstallerStateController{
+		controllerInstanceName:          factory.ControllerInstanceName(instanceName, "InstallerState"),
+		kubeInformersForTargetNamespace: kubeInformersForTargetNamespace,
+		podsGetter:                      podsGetter,
+		eventsGetter:                    eventsGetter,
+		targetNamespace:                 targetNamespace,
+		operatorClient:                  operatorClient,
+		timeNowFn:                       time.Now,
+		options:                         options,
+	}
+}
+
+// recordWarningf emits a Warning event for nodeName unless the last Warning emitted for that node
+// carried the same reason and message and was emitted within c.options.MinEventInterval. Only the
+// most recent Warning per node is remembered, so when one node gets several different warnings in
+// turn (for example a pending reason and a container waiting reason), each of them is emitted again.
+func (c *InstallerStateController) recordWarningf(recorder events.Recorder, nodeName, reason, message string) {
+	now := c.timeNowFn()
+	if last, ok := c.lastEventEmitted[nodeName]; ok {
+		if last.reason == reason && last.message == message && now.Sub(last.timestamp) < c.options.MinEventInterval {
+			return
+		}
+	}
+	if c.lastEventEmitted == nil {
+		c.lastEventEmitted = make(map[string]emittedEvent)
+	}
+	c.lastEventEmitted[nodeName] = emittedEvent{reason: reason, message: message, timestamp: now}
+	recorder.Warningf(reason, message)
+}
+
+// WithInstallPrecondition sets a callback that is consulted for each master
+// node with a pending installer pod once the pod has exceeded
+// options.PendingTolerationDuration, mirroring the precondition callback
+// pattern on library-go's InstallerController. If the callback returns
+// ok=false, the pod's generic InstallerPodPendingDegraded condition is
+// replaced with InstallerPodPreconditionNotMetDegraded carrying the
+// callback's reason and message. Errors from the callback are logged but do
+// not fail the sync.
+func (c *InstallerStateController) WithInstallPrecondition(fn InstallPreconditionFunc) *InstallerStateController {
+	c.installPrecondition = fn
+	return c
+}
+
+// ToController builds the factory.Controller that runs this controller's sync loop.
+func (c *InstallerStateController) ToController(recorder events.Recorder) factory.Controller {
+	return factory.New().
+		WithInformers(c.kubeInformersForTargetNamespace.Core().V1().Pods().Informer()).
+		WithSync(c.sync).
+		ResyncEvery(1*time.Minute).
+		WithControllerInstanceName(c.controllerInstanceName).
+		ToController(
+			c.controllerInstanceName,
+			recorder,
+		)
+}
+
+// degradedConditionNames lists all supported condition types.
+var degradedConditionNames = []string{
+	"InstallerPodPendingDegraded",
+	"InstallerPodContainerWaitingDegraded",
+	"InstallerPodNetworkingDegraded",
+	"InstallerPodPreemptedDegraded",
+	"InstallerPodTaintEvictedDegraded",
+	"InstallerPodAPIEvictedDegraded",
+	"InstallerPodGCDeletedDegraded",
+	"InstallerPodPreconditionNotMetDegraded",
+	"InstallerPodImagePullDegraded",
+}
+
+// imagePullWaitingReasons are container waiting reasons that indicate an image pull problem rather than
+// a container configuration problem, and are surfaced via InstallerPodImagePullDegraded instead of the
+// generic InstallerPodContainerWaitingDegraded.
+var imagePullWaitingReasons = map[string]bool{
+	"ImagePullBackOff":    true,
+	"ErrImagePull":        true,
+	"RegistryUnavailable": true,
+}
+
+// imagePullFailureSubReasons maps a substring found in a Failed event's message to the sub-reason
+// reported in the InstallerPodImagePullDegraded condition's Reason field, so operators can tell an
+// auth problem apart from a DNS, TLS, or missing-tag problem without reading the raw event.
+var imagePullFailureSubReasons = []struct {
+	substring string
+	subReason string
+}{
+	{"pull access denied", "AuthFailed"},
+	{"no such host", "DNSFailure"},
+	{"x509", "TLSFailure"},
+	{"manifest unknown", "ManifestMissing"},
+}
+
+// classifyImagePullFailure looks for a known substring in a Failed event's message and returns the
+// sub-reason it corresponds to.
+func classifyImagePullFailure(message string) (string, bool) {
+	for _, c := range imagePullFailureSubReasons {
+		if strings.Contains(message, c.substring) {
+			return c.subReason, true
+		}
+	}
+	return "", false
+}
+
+// disruptionReasonToConditionType maps the Reason carried by a pod's
+// DisruptionTarget condition to the degraded condition type that should be
+// surfaced for it, so operators can distinguish transient scheduler churn
+// from cluster-admin driven evictions.
+var disruptionReasonToConditionType = map[string]string{
+	"PreemptionByKubeScheduler": "InstallerPodPreemptedDegraded",
+	"DeletionByTaintManager":    "InstallerPodTaintEvictedDegraded",
+	"EvictionByEvictionAPI":     "InstallerPodAPIEvictedDegraded",
+	"DeletionByPodGC":           "InstallerPodGCDeletedDegraded",
+}
+
+// vanishedPodEventReasonToConditionType maps the Event reason recorded for an
+// installer pod that has already been deleted, and so can no longer be
+// inspected for a DisruptionTarget condition, to the degraded condition type
+// that should be surfaced for it.
+var vanishedPodEventReasonToConditionType = map[string]string{
+	"Preempted":            "InstallerPodPreemptedDegraded",
+	"TaintManagerEviction": "InstallerPodTaintEvictedDegraded",
+	"Evicted":              "InstallerPodAPIEvictedDegraded",
+}
+
+func installerNameToRevision(name string) (int, error) {
+	parts := strings.Split(name, "-")
+	if len(parts) < 2 {
+		return 0, fmt.Errorf("Installer name %v is invalid, missing revision number", name)
+	}
+	return strconv.Atoi(parts[1])
+}
+
+func (c *InstallerStateController) sync(ctx context.Context, syncCtx factory.SyncContext) error {
+	pods, err := c.podsGetter.Pods(c.targetNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{"app": "installer"}).String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	masterRevisions := make(map[string][]*v1.Pod)
+	installerHighestRunningRevision := make(map[string]int)
+	for _, pod := range pods.Items {
+		p := pod
+		masterRevisions[pod.Spec.NodeName] = append(masterRevisions[pod.Spec.NodeName], &p)
+	}
+	// find the highest revision of a non-pending pod on each master node
+	for masterNode, pods := range masterRevisions {
+		maxRunningRev := 0
+		for _, pod := range pods {
+			if pod.Status.Phase != v1.PodPending || pod.Status.StartTime == nil {
+				rev, err := installerNameToRevision(pod.Name)
+				if err != nil {
+					return err
+				}
+				if rev > maxRunningRev {
+					maxRunningRev = rev
+				}
+			}
+		}
+		installerHighestRunningRevision[masterNode] = maxRunningRev
+	}
+
+	// collect all startingObjects that are in pending state and at or above the highest running
+	// revision for their node; handlePendingInstallerPods applies its own toleration durations.
+	pendingCandidates := []*v1.Pod{}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != v1.PodPending || pod.Status.StartTime == nil {
+			continue
+		}
+		if rev, _ := installerNameToRevision(pod.Name); rev >= installerHighestRunningRevision[pod.Spec.NodeName] {
+			pendingCandidates = append(pendingCandidates, pod.DeepCopy())
+		}
+	}
+
+	// installer pods at or above the highest running revision for their node. Pods for an
+	// already-superseded revision are excluded so that PodGC or drain cleaning up a stale, already
+	// resolved installer pod can't flip a fresh disruption condition.
+	currentRevisionPods := []v1.Pod{}
+	for _, pod := range pods.Items {
+		if rev, _ := installerNameToRevision(pod.Name); rev >= installerHighestRunningRevision[pod.Spec.NodeName] {
+			currentRevisionPods = append(currentRevisionPods, pod)
+		}
+	}
+
+	// handle pending installer pods conditions
+	foundConditions := []operatorv1.OperatorCondition{}
+	foundConditions = append(foundConditions, c.handlePendingInstallerPods(ctx, syncCtx.Recorder(), pendingCandidates)...)
+
+	// pods tolerated long enough to be worth correlating with namespace events
+	pendingPods := []*v1.Pod{}
+	for _, pod := range pendingCandidates {
+		if c.timeNowFn().Sub(pod.Status.StartTime.Time) >= c.options.PendingTolerationDuration {
+			pendingPods = append(pendingPods, pod)
+		}
+	}
+
+	// handle networking conditions that are based on events
+	networkConditions, err := c.handlePendingInstallerPodsNetworkEvents(ctx, syncCtx.Recorder(), pendingPods)
+	if err != nil {
+		return err
+	}
+	foundConditions = append(foundConditions, networkConditions...)
+
+	// pods tolerated long enough for a waiting container to be worth checking for an image pull
+	// failure; gated on ContainerWaitingTolerationDuration like handlePendingInstallerPods' own check,
+	// not PendingTolerationDuration, so the two tolerations can be tuned independently.
+	imagePullCandidates := []*v1.Pod{}
+	for _, pod := range pendingCandidates {
+		if c.timeNowFn().Sub(pod.Status.StartTime.Time) >= c.options.ContainerWaitingTolerationDuration {
+			imagePullCandidates = append(imagePullCandidates, pod)
+		}
+	}
+
+	// handle image pull failures, classified by root cause using events
+	imagePullConditions, err := c.handleImagePullFailures(ctx, syncCtx.Recorder(), imagePullCandidates)
+	if err != nil {
+		return err
+	}
+	foundConditions = append(foundConditions, imagePullConditions...)
+
+	// handle pods disrupted by preemption, taint eviction, the eviction API, or PodGC. All pods still
+	// returned by the list are used to track which pods are still physically present (so a pod that
+	// merely ages out of currentRevisionPods on a successful rollout isn't mistaken for vanished); only
+	// currentRevisionPods are scanned for a live DisruptionTarget condition.
+	disruptionConditions, err := c.handleInstallerPodsDisruption(ctx, syncCtx.Recorder(), pods.Items, currentRevisionPods)
+	if err != nil {
+		return err
+	}
+	foundConditions = append(foundConditions, disruptionConditions...)
+
+	updateConditions := []*applyoperatorv1.OperatorConditionApplyConfiguration{}
+	// check the supported degraded foundConditions and check if any pending pod matching them.
+	for _, degradedConditionName := range degradedConditionNames {
+		// clean up existing foundConditions
+		updatedCondition := applyoperatorv1.OperatorCondition().
+			WithType(degradedConditionName).
+			WithStatus(operatorv1.ConditionFalse)
+
+		if condition := v1helpers.FindOperatorCondition(foundConditions, degradedConditionName); condition != nil {
+			updatedCondition = updatedCondition.
+				WithStatus(condition.Status).
+				WithReason(condition.Reason).
+				WithMessage(condition.Message)
+		}
+		updateConditions = append(updateConditions, updatedCondition)
+	}
+
+	status := applyoperatorv1.StaticPodOperatorStatus().WithConditions(updateConditions...)
+	return c.operatorClient.ApplyStaticPodOperatorStatus(ctx, c.controllerInstanceName, status)
+}
+
+func (c *InstallerStateController) handlePendingInstallerPodsNetworkEvents(ctx context.Context, recorder events.Recorder, pods []*v1.Pod) ([]operatorv1.OperatorCondition, error) {
+	conditions := []operatorv1.OperatorCondition{}
+	if len(pods) == 0 {
+		return conditions, nil
+	}
+	namespaceEvents, err := c.eventsGetter.Events(c.targetNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range namespaceEvents.Items {
+		if event.InvolvedObject.Kind != "Pod" {
+			continue
+		}
+		if !strings.Contains(event.Message, "failed to create pod network") {
+			continue
+		}
+		if c.options.NetworkEventLookbackWindow > 0 && c.timeNowFn().Sub(event.LastTimestamp.Time) > c.options.NetworkEventLookbackWindow {
+			continue
+		}
+		for _, pod := range pods {
+			if pod.Name != event.InvolvedObject.Name {
+				continue
+			}
+			// If we already find the pod that is pending because of the networking problem, skip other pods.
+			// This will reduce the events we fire.
+			if c := v1helpers.FindOperatorCondition(conditions, "InstallerPodNetworkingDegraded"); c != nil && c.Status == operatorv1.ConditionTrue {
+				break
+			}
+			condition := operatorv1.OperatorCondition{
+				Type:    "InstallerPodNetworkingDegraded",
+				Status:  operatorv1.ConditionTrue,
+				Reason:  event.Reason,
+				Message: fmt.Sprintf("Pod %q on node %q observed degraded networking: %s", pod.Name, pod.Spec.NodeName, event.Message),
+			}
+			conditions = append(conditions, condition)
+			c.recordWarningf(recorder, pod.Spec.NodeName, condition.Reason, condition.Message)
+		}
+	}
+	return conditions, nil
+}
+
+// handleImagePullFailures reports InstallerPodImagePullDegraded for pods whose container is waiting on
+// an image pull problem, classifying the root cause from any matching Failed event so operators can see
+// whether a broken mirror or tag is an auth, DNS, TLS, or missing-manifest problem.
+func (c *InstallerStateController) handleImagePullFailures(ctx context.Context, recorder events.Recorder, pods []*v1.Pod) ([]operatorv1.OperatorCondition, error) {
+	conditions := []operatorv1.OperatorCondition{}
+
+	var candidates []*v1.Pod
+	for _, pod := range pods {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.State.Waiting != nil && imagePullWaitingReasons[containerStatus.State.Waiting.Reason] {
+				candidates = append(candidates, pod)
+				break
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return conditions, nil
+	}
+
+	namespaceEvents, err := c.eventsGetter.Events(c.targetNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pod := range candidates {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			waiting := containerStatus.State.Waiting
+			if waiting == nil || !imagePullWaitingReasons[waiting.Reason] {
+				continue
+			}
+
+			reason := waiting.Reason
+			for _, event := range namespaceEvents.Items {
+				if event.InvolvedObject.Kind != "Pod" || event.InvolvedObject.Name != pod.Name || event.Reason != "Failed" {
+					continue
+				}
+				if subReason, ok := classifyImagePullFailure(event.Message); ok {
+					reason = subReason
+					break
+				}
+			}
+
+			condition := operatorv1.OperatorCondition{
+				Type:    "InstallerPodImagePullDegraded",
+				Status:  operatorv1.ConditionTrue,
+				Reason:  reason,
+				Message: fmt.Sprintf("Pod %q on node %q container %q is waiting on image %q because %s", pod.Name, pod.Spec.NodeName, containerStatus.Name, containerStatus.Image, waiting.Reason),
+			}
+			conditions = append(conditions, condition)
+			c.recordWarningf(recorder, pod.Spec.NodeName, condition.Reason, condition.Message)
+		}
+	}
+	return conditions, nil
+}
+
+// handleInstallerPodsDisruption inspects every current-revision installer pod's DisruptionTarget
+// condition and, for pods that have vanished since the previous sync, the namespace's events, to tell
+// transient scheduler churn (preemption) apart from cluster-admin driven evictions and PodGC cleanup.
+// allPods must be every installer pod still returned by the list (used only to build the presence map
+// that answers "did this pod actually disappear"); scanPods is the subset to scan for a live
+// DisruptionTarget condition. Restricting the presence map itself to scanPods would make a pod that
+// simply ages out of the current-revision filter on a successful rollout look "vanished".
+func (c *InstallerStateController) handleInstallerPodsDisruption(ctx context.Context, recorder events.Recorder, allPods []v1.Pod, scanPods []v1.Pod) ([]operatorv1.OperatorCondition, error) {
+	conditions := []operatorv1.OperatorCondition{}
+	seen := make(map[string]string, len(allPods))
+	for _, pod := range allPods {
+		seen[pod.Name] = pod.Spec.NodeName
+	}
+	// only scanPods are remembered for the next sync's vanished check, so a superseded installer pod
+	// that is cleaned up later is never reported as disrupted.
+	tracked := make(map[string]string, len(scanPods))
+	for _, pod := range scanPods {
+		tracked[pod.Name] = pod.Spec.NodeName
+	}
+	for _, pod := range scanPods {
+		for _, podCondition := range pod.Status.Conditions {
+			if podCondition.Type != v1.DisruptionTarget || podCondition.Status != v1.ConditionTrue {
+				continue
+			}
+			conditionType, ok := disruptionReasonToConditionType[podCondition.Reason]
+			if !ok {
+				continue
+			}
+			condition := operatorv1.OperatorCondition{
+				Type:    conditionType,
+				Status:  operatorv1.ConditionTrue,
+				Reason:  podCondition.Reason,
+				Message: fmt.Sprintf("Pod %q on node %q was disrupted: %s", pod.Name, pod.Spec.NodeName, podCondition.Message),
+			}
+			conditions = append(conditions, condition)
+			c.recordWarningf(recorder, pod.Spec.NodeName, condition.Reason, condition.Message)
+		}
+	}
+
+	vanishedConditions, err := c.handleVanishedInstallerPods(ctx, recorder, seen)
+	if err != nil {
+		return nil, err
+	}
+	conditions = append(conditions, vanishedConditions...)
+
+	c.lastSeenPodToNode = tracked
+	return conditions, nil
+}
+
+// handleVanishedInstallerPods looks for installer pods that were present in
+// the previous sync but are no longer returned by the pod list, e.g. because
+// PodGC deleted them before we could observe their DisruptionTarget
+// condition, and attributes their disruption from the most recent matching
+// event in targetNamespace.
+func (c *InstallerStateController) handleVanishedInstallerPods(ctx context.Context, recorder events.Recorder, currentPods map[string]string) ([]operatorv1.OperatorCondition, error) {
+	conditions := []operatorv1.OperatorCondition{}
+	if len(c.lastSeenPodToNode) == 0 {
+		return conditions, nil
+	}
+
+	var namespaceEvents *v1.EventList
+	for podName, nodeName := range c.lastSeenPodToNode {
+		if _, stillPresent := currentPods[podName]; stillPresent {
+			continue
+		}
+		if namespaceEvents == nil {
+			var err error
+			namespaceEvents, err = c.eventsGetter.Events(c.targetNamespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		var latest *v1.Event
+		for i := range namespaceEvents.Items {
+			event := &namespaceEvents.Items[i]
+			if event.InvolvedObject.Kind != "Pod" || event.InvolvedObject.Name != podName {
+				continue
+			}
+			if _, ok := vanishedPodEventReasonToConditionType[event.Reason]; !ok {
+				continue
+			}
+			if latest == nil || event.LastTimestamp.Time.After(latest.LastTimestamp.Time) {
+				latest = event
+			}
+		}
+		if latest == nil {
+			continue
+		}
+
+		condition := operatorv1.OperatorCondition{
+			Type:    vanishedPodEventReasonToConditionType[latest.Reason],
+			Status:  operatorv1.ConditionTrue,
+			Reason:  latest.Reason,
+			Message: fmt.Sprintf("Pod %q on node %q was disrupted: %s", podName, nodeName, latest.Message),
+		}
+		conditions = append(conditions, condition)
+		c.recordWarningf(recorder, nodeName, condition.Reason, condition.Message)
+	}
+	return conditions, nil
+}
+
+func (c *InstallerStateController) handlePendingInstallerPods(ctx context.Context, recorder events.Recorder, pods []*v1.Pod) []operatorv1.OperatorCondition {
+	conditions := []operatorv1.OperatorCondition{}
+	for _, pod := range pods {
+		elapsed := c.timeNowFn().Sub(pod.Status.StartTime.Time)
+
+		if elapsed >= c.options.PendingTolerationDuration {
+			if c.installPrecondition != nil {
+				ok, reason, message, err := c.installPrecondition(ctx, pod.Spec.NodeName)
+				if err != nil {
+					klog.Warningf("install precondition check for node %q failed: %v", pod.Spec.NodeName, err)
+				} else if !ok {
+					condition := operatorv1.OperatorCondition{
+						Type:    "InstallerPodPreconditionNotMetDegraded",
+						Reason:  reason,
+						Status:  operatorv1.ConditionTrue,
+						Message: message,
+					}
+					conditions = append(conditions, condition)
+					c.recordWarningf(recorder, pod.Spec.NodeName, condition.Reason, condition.Message)
+					continue
+				}
+			}
+
+			// the pod is in the pending state for longer than options.PendingTolerationDuration, report the
+			// reason and message as degraded condition for the operator.
+			if len(pod.Status.Reason) > 0 {
+				condition := operatorv1.OperatorCondition{
+					Type:    "InstallerPodPendingDegraded",
+					Reason:  pod.Status.Reason,
+					Status:  operatorv1.ConditionTrue,
+					Message: fmt.Sprintf("Pod %q on node %q is Pending since %s because %s", pod.Name, pod.Spec.NodeName, pod.Status.StartTime.Time, pod.Status.Message),
+				}
+				conditions = append(conditions, condition)
+				c.recordWarningf(recorder, pod.Spec.NodeName, condition.Reason, condition.Message)
+			}
+		}
+
+		if elapsed < c.options.ContainerWaitingTolerationDuration {
+			continue
+		}
+
+		// one or more containers are in waiting state for longer than options.ContainerWaitingTolerationDuration,
+		// report the reason and message as degraded condition for the operator.
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.State.Waiting == nil {
+				continue
+			}
+			if state := containerStatus.State.Waiting; len(state.Reason) > 0 && !imagePullWaitingReasons[state.Reason] {
+				message := fmt.Sprintf("Pod %q on node %q container %q is waiting since %s because", pod.Name, pod.Spec.NodeName, containerStatus.Name, pod.Status.StartTime.Time)
+				if len(state.Message) > 0 {
+					message = fmt.Sprintf("%s %q", message, state.Message)
+				} else {
+					message = fmt.Sprintf("%s %s", message, state.Reason)
+				}
+				condition := operatorv1.OperatorCondition{
+					Type:    "InstallerPodContainerWaitingDegraded",
+					Reason:  state.Reason,
+					Status:  operatorv1.ConditionTrue,
+					Message: message,
+				}
+				conditions = append(conditions, condition)
+				c.recordWarningf(recorder, pod.Spec.NodeName, condition.Reason, condition.Message)
+			}
+		}
+	}
+
+	return conditions
+}